@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxKeptVersions is how many previously active /usr/local/go-<version>
+// directories systemInstaller keeps around after a successful install,
+// beyond the one currently active, so 'go-updater rollback' has somewhere
+// to go back to. 'go-updater gc' prunes anything older than that.
+const maxKeptVersions = 2
+
+// versionedInstallDir is the sibling directory a specific version is
+// extracted into and activated from, e.g. "/usr/local/go-1.22.6" for root
+// "/usr/local/go" and version "go1.22.6".
+func versionedInstallDir(root, version string) string {
+	return root + "-" + strings.TrimPrefix(version, "go")
+}
+
+// installedVersionDirs lists every "<root>-<version>" sibling directory on
+// disk, most recently modified first. Staging directories (suffixed
+// ".new") are never considered installed.
+func installedVersionDirs(root string) ([]string, error) {
+	matches, err := filepath.Glob(root + "-*")
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, m := range matches {
+		if strings.HasSuffix(m, ".new") {
+			continue
+		}
+		if fi, err := os.Stat(m); err == nil && fi.IsDir() {
+			dirs = append(dirs, m)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		fi, erri := os.Stat(dirs[i])
+		fj, errj := os.Stat(dirs[j])
+		if erri != nil || errj != nil {
+			return erri == nil
+		}
+		return fi.ModTime().After(fj.ModTime())
+	})
+	return dirs, nil
+}
+
+// pruneOldVersions removes every installed version directory beyond the
+// `keep` most recently modified ones, always leaving the currently active
+// version alone regardless of its age.
+func pruneOldVersions(plat platform, root string, keep int) error {
+	dirs, err := installedVersionDirs(root)
+	if err != nil {
+		return err
+	}
+	active := plat.activeTarget(root)
+
+	kept := 0
+	for _, d := range dirs {
+		if d == active {
+			continue
+		}
+		kept++
+		if kept > keep {
+			log("Pruning old install %s", d)
+			if err := plat.removeInstall(d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncDir fsyncs the directory itself, so its entries are on disk before
+// the rename that makes a version eligible for activation. The files and
+// subdirectories beneath dir are not synced individually.
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
+
+// Install extracts version into its own versioned directory and only then
+// atomically activates it, so a failed download or extract never disturbs
+// the previously active toolchain:
+//
+//  1. extract into <target>.new (a scratch dir, never activated)
+//  2. fsync it and rename it to <target>, making it a candidate
+//  3. flip root to point at <target>
+//  4. prune old versions beyond maxKeptVersions
+//
+// Anything going wrong before step 3 leaves the previous install active
+// and untouched; main rolls step 3 back automatically if the freshly
+// activated 'go version' doesn't check out.
+func (systemInstaller) Install(version, tarPath string) (string, error) {
+	plat, err := currentPlatform()
+	if err != nil {
+		return "", err
+	}
+	root := plat.installRoot()
+	target := versionedInstallDir(root, version)
+	staging := target + ".new"
+
+	if err := plat.removeInstall(staging); err != nil {
+		return "", fmt.Errorf("clear stale staging dir %s: %w", staging, err)
+	}
+	if err := plat.installPrivileged(tarPath, staging); err != nil {
+		plat.removeInstall(staging)
+		return "", fmt.Errorf("extract archive to %s: %w", staging, err)
+	}
+	if err := syncDir(staging); err != nil {
+		plat.removeInstall(staging)
+		return "", fmt.Errorf("sync %s to disk: %w", staging, err)
+	}
+
+	// If target already exists (e.g. reinstalling a version gc kept but
+	// never activated), move it aside instead of removing it outright, so
+	// a failed rename below still leaves a valid directory behind to
+	// restore rather than having destroyed it before its replacement was
+	// safely in place.
+	backup := target + ".bak"
+	hadExisting := false
+	if _, statErr := os.Stat(target); statErr == nil {
+		hadExisting = true
+		if err := plat.removeInstall(backup); err != nil {
+			plat.removeInstall(staging)
+			return "", fmt.Errorf("clear stale backup dir %s: %w", backup, err)
+		}
+		if err := plat.renameDir(target, backup); err != nil {
+			plat.removeInstall(staging)
+			return "", fmt.Errorf("move aside previous %s: %w", target, err)
+		}
+	}
+	if err := plat.renameDir(staging, target); err != nil {
+		plat.removeInstall(staging)
+		if hadExisting {
+			if rbErr := plat.renameDir(backup, target); rbErr != nil {
+				warn("restore previous install %s after failed commit: %v", target, rbErr)
+			}
+		}
+		return "", fmt.Errorf("commit %s to %s: %w", staging, target, err)
+	}
+	if hadExisting {
+		if err := plat.removeInstall(backup); err != nil {
+			warn("remove backup of previous install %s: %v", backup, err)
+		}
+	}
+
+	if err := plat.activate(root, target); err != nil {
+		return "", fmt.Errorf("activate %s: %w", target, err)
+	}
+
+	if err := pruneOldVersions(plat, root, maxKeptVersions); err != nil {
+		warn("prune old installs: %v", err)
+	}
+	return root, nil
+}
+
+// Rollback flips root back to the most recently modified version directory
+// still on disk other than the current one (see installedVersionDirs).
+func (systemInstaller) Rollback() error {
+	plat, err := currentPlatform()
+	if err != nil {
+		return err
+	}
+	root := plat.installRoot()
+	dirs, err := installedVersionDirs(root)
+	if err != nil {
+		return err
+	}
+	active := plat.activeTarget(root)
+
+	for _, d := range dirs {
+		if d == active {
+			continue
+		}
+		if err := plat.activate(root, d); err != nil {
+			return fmt.Errorf("activate %s: %w", d, err)
+		}
+		log("Rolled back %s to %s", root, d)
+		return nil
+	}
+	return errors.New("no previous version available to roll back to")
+}
+
+// GC prunes installed version directories beyond the most recent `keep`,
+// independently of any install just having happened.
+func (systemInstaller) GC(keep int) error {
+	plat, err := currentPlatform()
+	if err != nil {
+		return err
+	}
+	return pruneOldVersions(plat, plat.installRoot(), keep)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// downloadOptions controls how downloadFile fetches a file: the manifest
+// sha256 to verify against for a fresh (non-resumed) download, whether to
+// print a progress bar, and how many attempts to make before giving up.
+type downloadOptions struct {
+	expectedSHA256 string
+	quiet          bool
+	maxAttempts    int
+}
+
+const (
+	downloadUserAgent  = "go-updater/1.0 (https://github.com/L1ghtn1ng/go-updater)"
+	readDeadline       = 30 * time.Second
+	downloadBackoffMin = 1 * time.Second
+	downloadBackoffMax = 30 * time.Second
+)
+
+// downloadFile fetches url into toPath. It writes to "<toPath>.part" and
+// only renames that into toPath once the transfer (and, for fresh
+// downloads, its checksum) is confirmed good, so an interrupted or failed
+// run never leaves a file at toPath that looks complete but isn't.
+//
+// It resumes an existing .part file with a Range request when the server
+// supports it, retries transient failures with exponential backoff up to
+// opts.maxAttempts times, and enforces a per-read deadline rather than a
+// whole-request timeout so a multi-GB download on a slow link doesn't fail
+// just because it's slow.
+func downloadFile(url, toPath string, opts downloadOptions) error {
+	if opts.maxAttempts <= 0 {
+		opts.maxAttempts = 5
+	}
+	partPath := toPath + ".part"
+
+	length, acceptsRanges, err := probeDownload(url)
+	if err != nil {
+		return fmt.Errorf("probe %s: %w", url, err)
+	}
+
+	backoff := downloadBackoffMin
+	var lastErr error
+	for attempt := 1; attempt <= opts.maxAttempts; attempt++ {
+		resumeFrom := int64(0)
+		if acceptsRanges {
+			if fi, statErr := os.Stat(partPath); statErr == nil {
+				resumeFrom = fi.Size()
+			}
+		} else {
+			os.Remove(partPath) // server can't resume; always start clean
+		}
+
+		if lastErr = attemptDownload(url, partPath, resumeFrom, length, opts); lastErr == nil {
+			break
+		}
+		warn("download attempt %d/%d failed: %v", attempt, opts.maxAttempts, lastErr)
+		if attempt < opts.maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > downloadBackoffMax {
+				backoff = downloadBackoffMax
+			}
+		}
+	}
+	if lastErr != nil {
+		return fmt.Errorf("download %s: %w", url, lastErr)
+	}
+
+	if err := os.Rename(partPath, toPath); err != nil {
+		return fmt.Errorf("finalize download %s: %w", toPath, err)
+	}
+	return nil
+}
+
+// probeDownload issues a HEAD request to learn the expected size and
+// whether the server honors Range requests, so downloadFile knows whether
+// resuming an existing .part file is worth attempting.
+func probeDownload(url string) (length int64, acceptsRanges bool, err error) {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("User-Agent", downloadUserAgent)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("HEAD %s: HTTP %d", url, resp.StatusCode)
+	}
+	return resp.ContentLength, resp.Header.Get("Accept-Ranges") == "bytes", nil
+}
+
+// attemptDownload makes a single GET attempt, appending to partPath from
+// resumeFrom (0 for a fresh download) and reporting progress against
+// totalLength (0 if the server didn't report one).
+func attemptDownload(url, partPath string, resumeFrom, totalLength int64, opts downloadOptions) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", downloadUserAgent)
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	// No client-wide Timeout: a per-read deadline (deadlineReader, below)
+	// bounds stalls without capping how long the whole transfer is allowed
+	// to take, which is what a slow-but-steady multi-GB download needs.
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		resumeFrom = 0 // server ignored our Range header; start over
+	case http.StatusPartialContent:
+		// resuming as requested
+	default:
+		return fmt.Errorf("GET %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if resumeFrom > 0 {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	out, err := os.OpenFile(partPath, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// Streaming verification only makes sense starting from byte zero; a
+	// resumed download is checked the ordinary way afterwards, against the
+	// manifest sha256, by the caller (verifyArchive).
+	var h hash.Hash
+	var hashWriter io.Writer = io.Discard
+	if opts.expectedSHA256 != "" && resumeFrom == 0 {
+		h = sha256.New()
+		hashWriter = h
+	}
+
+	bar := newProgressBar(totalLength, resumeFrom, opts.quiet)
+	reader := &deadlineReader{r: resp.Body, timeout: readDeadline, cancel: cancel}
+
+	if _, err := io.Copy(io.MultiWriter(out, hashWriter, bar), reader); err != nil {
+		bar.finish()
+		return err
+	}
+	bar.finish()
+
+	if h != nil {
+		if sum := hex.EncodeToString(h.Sum(nil)); sum != opts.expectedSHA256 {
+			os.Remove(partPath)
+			return fmt.Errorf("sha256 mismatch: got %s, want %s", sum, opts.expectedSHA256)
+		}
+	}
+	return nil
+}
+
+// deadlineReader fails a single Read once it runs longer than timeout,
+// rather than bounding the whole stream the way a client-wide
+// http.Client.Timeout would -- which is exactly what made multi-GB
+// downloads on slow links fail spuriously.
+type deadlineReader struct {
+	r       io.Reader
+	timeout time.Duration
+	cancel  context.CancelFunc
+}
+
+func (dr *deadlineReader) Read(p []byte) (int, error) {
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := dr.r.Read(p)
+		done <- result{n, err}
+	}()
+	select {
+	case res := <-done:
+		return res.n, res.err
+	case <-time.After(dr.timeout):
+		dr.cancel()
+		return 0, fmt.Errorf("no data received for %s", dr.timeout)
+	}
+}
+
+// isTerminal reports whether f is attached to a terminal, so the progress
+// bar only renders for interactive use and stays out of piped or
+// redirected-to-a-file output.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -22,9 +22,61 @@ func main() {
 		noPathUpdate    = flag.Bool("no-path-update", false, "Do not modify profile files to add /usr/local/go/bin to PATH.")
 		systemPathFlag  = flag.Bool("system", false, "Also add PATH entry system-wide under /etc/profile.d (requires sudo).")
 		downloadDirFlag = flag.String("download-dir", "", "Directory to place the downloaded archive (defaults to system temp dir).")
+		verifySigFlag   = flag.Bool("verify-signature", false, "Additionally verify the downloaded archive against its detached GPG signature. The bundled release key is a stand-in that fails its fingerprint check, so this flag exits with an error before anything is downloaded.")
+		quietFlag       = flag.Bool("quiet", false, "Suppress the download progress bar.")
+
+		watchFlag          = flag.Bool("watch", false, "Run continuously, polling go.dev for new releases instead of exiting after one check.")
+		watchIntervalFlag  = flag.Duration("watch-interval", time.Hour, "How often --watch polls go.dev for new releases.")
+		autoInstallFlag    = flag.String("auto-install", "none", "Under --watch, automatically install new releases: 'patch', 'minor', or 'none' (notify only).")
+		webhookURLFlag     = flag.String("webhook-url", "", "Under --watch, POST a JSON {old, new, changelog_url} notification to this URL on a new release.")
+		installServiceFlag = flag.Bool("install-service", false, "Install a systemd user unit (Linux) or launchd agent (macOS) running 'go-updater --watch', then exit.")
 	)
 	flag.Parse()
 
+	if *verifySigFlag {
+		must(ensureReleaseKeyIsGenuine(), "--verify-signature")
+	}
+
+	// Subcommands install/list/uninstall drive the multi-version SDK manager
+	// under ~/sdk and leave /usr/local/go alone; 'use' repoints /usr/local/go
+	// at an SDK version, and rollback/gc act on the /usr/local/go-<version>
+	// directories. With no subcommand we fall back to the original
+	// single-shot "update /usr/local/go" flow.
+	if args := flag.Args(); len(args) > 0 {
+		if err := runSDKCommand(args, *quietFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "[go-updater][ERROR] %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *installServiceFlag {
+		watchArgs := []string{"--watch", "--auto-install=" + *autoInstallFlag}
+		if *webhookURLFlag != "" {
+			watchArgs = append(watchArgs, "--webhook-url="+*webhookURLFlag)
+		}
+		if *quietFlag {
+			watchArgs = append(watchArgs, "--quiet")
+		}
+		must(installService(watchArgs), "install background service")
+		return
+	}
+
+	if *watchFlag {
+		policy, err := parseAutoInstallPolicy(*autoInstallFlag)
+		must(err, "parse --auto-install")
+
+		notifiers := []notifier{stdoutNotifier{}}
+		if *webhookURLFlag != "" {
+			notifiers = append(notifiers, webhookNotifier{url: *webhookURLFlag})
+		}
+		if runtime.GOOS == "linux" {
+			notifiers = append(notifiers, notifySendNotifier{})
+		}
+		must(watch(*watchIntervalFlag, policy, notifiers, *quietFlag), "watch for new releases")
+		return
+	}
+
 	// Determine target version
 	var version string
 	var err error
@@ -46,8 +98,11 @@ func main() {
 	goos, goarch, err := resolveTarget()
 	must(err, "resolve target platform")
 
-	tarName := fmt.Sprintf("%s.%s-%s.tar.gz", version, goos, goarch)
-	url := "https://go.dev/dl/" + tarName
+	plat, err := currentPlatform()
+	must(err, "select platform")
+
+	tarName := fmt.Sprintf("%s.%s-%s.%s", version, goos, goarch, plat.archiveExt())
+	url := archiveDownloadURL(tarName)
 
 	// Decide download dir
 	dlDir := *downloadDirFlag
@@ -62,44 +117,62 @@ func main() {
 	log("Download: %s\n       to: %s", url, tarPath)
 
 	if *dryRunFlag {
-		printPlan(version, goos, goarch, url, tarPath, *noPathUpdate, *systemPathFlag)
+		printPlan(plat, version, goos, goarch, url, tarPath, *noPathUpdate, *systemPathFlag, *verifySigFlag)
 		return
 	}
 
-	// Download the archive if not already present
-	if _, err := os.Stat(tarPath); err == nil {
-		log("Using existing archive: %s", tarPath)
-	} else {
-		must(downloadFile(url, tarPath), "download archive")
-		log("Downloaded: %s", tarPath)
-	}
+	// Download (if needed) and verify the archive against the go.dev
+	// release manifest before trusting it.
+	must(resolveArchive(version, goos, goarch, tarPath, url, *quietFlag), "resolve and verify archive")
 
-	// Remove any previous installation
-	must(runAsRoot("rm", "-rf", "/usr/local/go"), "remove previous /usr/local/go")
+	if *verifySigFlag {
+		must(verifySignature(tarPath, url), "verify archive signature")
+		log("Verified GPG signature (key %s)", goReleaseKeyFingerprint)
+	}
 
-	// Extract archive into /usr/local
-	must(runAsRoot("tar", "-C", "/usr/local", "-xzf", tarPath), "extract archive to /usr/local")
-	log("Extracted to /usr/local/go")
+	// Install into /usr/local/go. This is the 'system' Installer mode; the
+	// sdk subcommands use the sdkInstaller mode instead.
+	goroot, err := systemInstaller{}.Install(version, tarPath)
+	must(err, "install archive to /usr/local/go")
+	log("Extracted to %s", goroot)
 
-	// Ensure PATH contains /usr/local/go/bin
+	// Ensure PATH contains <goroot>/bin
+	goBin := filepath.Join(goroot, "bin")
 	if !*noPathUpdate {
-		must(ensureUserPath(), "ensure user PATH in ~/.profile")
+		must(plat.ensureUserPath(goroot), "ensure user PATH")
 		if *systemPathFlag {
-			if err := ensureSystemPath(); err != nil {
+			if err := plat.ensureSystemPath(goroot); err != nil {
 				warn("system-wide PATH update failed: %v", err)
 			}
 		}
 		// Make the current process aware for immediate verification
-		os.Setenv("PATH", os.Getenv("PATH")+string(os.PathListSeparator)+"/usr/local/go/bin")
+		os.Setenv("PATH", os.Getenv("PATH")+string(os.PathListSeparator)+goBin)
 	}
 
-	// Verify installation using an absolute path (PATH-independent)
-	out, err := exec.Command("/usr/local/go/bin/go", "version").CombinedOutput()
-	must(err, "verify: running '/usr/local/go/bin/go version'\nOutput: %s", string(out))
+	// Verify installation using an absolute path (PATH-independent). A
+	// failed or mismatched verification rolls the install back to whatever
+	// was active before this run rather than leaving a broken toolchain in
+	// place.
+	goBinary := filepath.Join(goBin, goBinaryName())
+	out, err := exec.Command(goBinary, "version").CombinedOutput()
+	if err != nil {
+		rb := systemInstaller{}
+		if rbErr := rb.Rollback(); rbErr != nil {
+			warn("rollback after failed verification also failed: %v", rbErr)
+		} else {
+			warn("verification failed; rolled back to the previous install")
+		}
+		must(err, "verify: running '%s version'\nOutput: %s", goBinary, string(out))
+	}
 	fmt.Print(string(out))
 
 	if !strings.Contains(string(out), version) {
-		warn("Installed Go reported '%s' which does not contain expected version '%s'", strings.TrimSpace(string(out)), version)
+		warn("Installed Go reported '%s' which does not contain expected version '%s'; rolling back", strings.TrimSpace(string(out)), version)
+		rb := systemInstaller{}
+		if rbErr := rb.Rollback(); rbErr != nil {
+			warn("rollback failed: %v", rbErr)
+		}
+		must(fmt.Errorf("reported %q, want %s", strings.TrimSpace(string(out)), version), "verify installed version")
 	}
 
 	log("Go %s installed successfully.", version)
@@ -108,7 +181,7 @@ func main() {
 
 func fetchLatestVersion() (string, error) {
 	const vURL = "https://go.dev/VERSION?m=text"
-	client := new(http.Client{Timeout: 15 * time.Second})
+	client := &http.Client{Timeout: 15 * time.Second}
 	resp, err := client.Get(vURL)
 	if err != nil {
 		return "", err
@@ -135,18 +208,21 @@ func resolveTarget() (string, string, error) {
 	goarch := runtime.GOARCH
 
 	switch goos {
-	case "linux", "darwin":
+	case "linux", "darwin", "freebsd", "windows":
 	default:
-		return "", "", fmt.Errorf("unsupported OS: %s (only linux/darwin supported by this installer)", goos)
+		return "", "", fmt.Errorf("unsupported OS: %s", goos)
 	}
 
-	// Map any special arch values to Go download naming if needed
+	// Map Go's runtime names (and a few common uname-style aliases) onto
+	// the arch strings go.dev publishes archives under.
 	switch goarch {
-	case "amd64", "arm64", "386":
+	case "amd64", "arm64", "386", "ppc64le", "riscv64", "s390x":
 	case "x86_64":
 		goarch = "amd64"
 	case "aarch64":
 		goarch = "arm64"
+	case "arm", "armv6l", "armv7l":
+		goarch = "armv6l"
 	default:
 		return "", "", fmt.Errorf("unsupported arch: %s", goarch)
 	}
@@ -154,179 +230,6 @@ func resolveTarget() (string, string, error) {
 	return goos, goarch, nil
 }
 
-func downloadFile(url, toPath string) error {
-	out, err := os.Create(toPath)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("User-Agent", "go-updater/1.0 (https://github.com/L1ghtn1ng/go-updater)")
-
-	client := new(http.Client{Timeout: 0})
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed: %s -> HTTP %d", url, resp.StatusCode)
-	}
-
-	_, err = io.Copy(out, resp.Body)
-	return err
-}
-
-func ensureUserPath() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-	line := "export PATH=$PATH:/usr/local/go/bin"
-
-	var candidates []string
-	if runtime.GOOS == "darwin" {
-		candidates = []string{".zprofile", ".zshrc", ".bash_profile", ".profile"}
-	} else {
-		candidates = []string{".profile"}
-	}
-
-	// If any existing file already contains the PATH, do nothing.
-	for _, name := range candidates {
-		path := filepath.Join(home, name)
-		if data, err := os.ReadFile(path); err == nil {
-			if containsProfileLine(string(data), line) {
-				log("User PATH already contains /usr/local/go/bin in %s", path)
-				return nil
-			}
-		}
-	}
-
-	// Append to the first existing file among candidates.
-	for _, name := range candidates {
-		path := filepath.Join(home, name)
-		if _, err := os.Stat(path); err == nil {
-			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-			writer := bufio.NewWriter(f)
-			fmt.Fprintln(writer)
-			fmt.Fprintln(writer, "# Added by go-updater to expose Go binaries")
-			fmt.Fprintln(writer, line)
-			if err := writer.Flush(); err != nil {
-				return err
-			}
-			log("Added PATH update to %s", path)
-			return nil
-		}
-	}
-
-	// Otherwise create the first candidate and write to it.
-	target := filepath.Join(home, candidates[0])
-	f, err := os.OpenFile(target, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	writer := bufio.NewWriter(f)
-	fmt.Fprintln(writer)
-	fmt.Fprintln(writer, "# Added by go-updater to expose Go binaries")
-	fmt.Fprintln(writer, line)
-	if err := writer.Flush(); err != nil {
-		return err
-	}
-	log("Added PATH update to %s", target)
-	return nil
-}
-
-func containsProfileLine(content, target string) bool {
-	// consider whitespace variations
-	for line := range strings.SplitSeq(content, "\n") {
-		line = strings.TrimSpace(line)
-		if line == target {
-			return true
-		}
-		// allow forms like: export PATH=/usr/local/go/bin:$PATH or similar
-		if strings.Contains(line, "/usr/local/go/bin") && strings.Contains(line, "export PATH") {
-			return true
-		}
-	}
-	return false
-}
-
-func ensureSystemPath() error {
-	if runtime.GOOS == "darwin" {
-		// Prefer /etc/paths.d on macOS
-		content := "/usr/local/go/bin\n"
-
-		tmp, err := os.CreateTemp("", "golang-path-*.txt")
-		if err != nil {
-			return err
-		}
-		tmpPath := tmp.Name()
-		if _, err := tmp.WriteString(content); err != nil {
-			tmp.Close()
-			return err
-		}
-		tmp.Close()
-
-		// Try /etc/paths.d first
-		if err := runAsRoot("install", "-m", "0644", tmpPath, "/etc/paths.d/go"); err == nil {
-			os.Remove(tmpPath)
-			log("Added system PATH at /etc/paths.d/go")
-			return nil
-		}
-
-		// Fallback: append to /etc/zprofile
-		cmd := fmt.Sprintf("printf '%s' >> /etc/zprofile", strings.ReplaceAll("export PATH=\"$PATH:/usr/local/go/bin\"\n", "'", "'\\''"))
-		if err := runAsRoot("sh", "-c", cmd); err != nil {
-			os.Remove(tmpPath)
-			return fmt.Errorf("failed to update /etc/paths.d or /etc/zprofile: %w", err)
-		}
-		os.Remove(tmpPath)
-		log("Appended system PATH to /etc/zprofile")
-		return nil
-	}
-
-	// Linux and others: use /etc/profile.d
-	content := "# /etc/profile.d/golang-path.sh\n# Added by go-updater\nexport PATH=\"$PATH:/usr/local/go/bin\"\n"
-
-	tmp, err := os.CreateTemp("", "golang-path-*.sh")
-	if err != nil {
-		return err
-	}
-	tmpPath := tmp.Name()
-	if _, err := tmp.WriteString(content); err != nil {
-		tmp.Close()
-		return err
-	}
-	tmp.Close()
-
-	// Try /etc/profile.d first
-	if err := runAsRoot("install", "-m", "0644", tmpPath, "/etc/profile.d/golang-path.sh"); err == nil {
-		os.Remove(tmpPath)
-		log("Added system PATH at /etc/profile.d/golang-path.sh")
-		return nil
-	}
-
-	// Fallback: append to /etc/profile
-	cmd := fmt.Sprintf("printf '%s' >> /etc/profile", strings.ReplaceAll(content, "'", "'\\''"))
-	if err := runAsRoot("sh", "-c", cmd); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("failed to update /etc/profile.d or /etc/profile: %w", err)
-	}
-	os.Remove(tmpPath)
-	log("Appended system PATH to /etc/profile")
-	return nil
-}
-
 func runAsRoot(cmd string, args ...string) error {
 	if isRoot() {
 		execCmd := exec.Command(cmd, args...)
@@ -353,25 +256,26 @@ func isRoot() bool {
 	return os.Geteuid() == 0
 }
 
-func printPlan(version, goos, goarch, url, tarPath string, noPath, system bool) {
+func printPlan(plat platform, version, goos, goarch, url, tarPath string, noPath, system, verifySig bool) {
+	root := plat.installRoot()
 	fmt.Println("Plan (dry-run):")
 	fmt.Printf("- Determine version: %s\n", version)
 	fmt.Printf("- Download %s -> %s\n", url, tarPath)
-	fmt.Println("- Remove any previous /usr/local/go")
-	fmt.Printf("- Extract archive into /usr/local\n")
+	fmt.Println("- Verify sha256 and size against the go.dev release manifest")
+	if verifySig {
+		fmt.Println("- Verify detached GPG signature against the bundled Go release signing key")
+	}
+	fmt.Printf("- Extract archive into %s (never touching the currently active install)\n", versionedInstallDir(root, version))
+	fmt.Printf("- Atomically activate it at %s, rolling back on verification failure\n", root)
 	if !noPath {
-		fmt.Println("- Add '/usr/local/go/bin' to PATH in your shell profile (idempotent)")
+		fmt.Printf("- Add '%s/bin' to PATH (idempotent)\n", root)
 		if system {
-			if goos == "darwin" {
-				fmt.Println("- Also add system-wide PATH via /etc/paths.d (requires sudo)")
-			} else {
-				fmt.Println("- Also add system-wide PATH via /etc/profile.d (requires sudo)")
-			}
+			fmt.Println("- Also add system-wide PATH (requires elevated privileges)")
 		}
 	} else {
 		fmt.Println("- Skip PATH update (per --no-path-update)")
 	}
-	fmt.Println("- Verify with '/usr/local/go/bin/go version'")
+	fmt.Printf("- Verify with '%s/bin/%s version'\n", root, goBinaryName())
 }
 
 func log(format string, args ...any) {
@@ -430,16 +334,18 @@ func cleanVersionInput(versionInput string) string {
 }
 
 // getInstalledGoVersion tries to detect the currently installed Go version.
-// It prefers /usr/local/go/bin/go (managed by this installer) and falls back
-// to any 'go' found in PATH. It returns a version string like 'go1.26.0'.
+// It prefers the platform's managed install location and falls back to any
+// 'go' found in PATH. It returns a version string like 'go1.26.0'.
 func getInstalledGoVersion() (string, error) {
 	// Prefer the standard installation location first
-	const stdGo = "/usr/local/go/bin/go"
-	if fi, err := os.Stat(stdGo); err == nil && !fi.IsDir() {
-		out, err := exec.Command(stdGo, "version").CombinedOutput()
-		if err == nil {
-			if v, perr := parseGoVersionOutput(string(out)); perr == nil {
-				return v, nil
+	if plat, err := currentPlatform(); err == nil {
+		stdGo := filepath.Join(plat.installRoot(), "bin", goBinaryName())
+		if fi, err := os.Stat(stdGo); err == nil && !fi.IsDir() {
+			out, err := exec.Command(stdGo, "version").CombinedOutput()
+			if err == nil {
+				if v, perr := parseGoVersionOutput(string(out)); perr == nil {
+					return v, nil
+				}
 			}
 		}
 	}
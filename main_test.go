@@ -65,28 +65,3 @@ func TestParseGoVersionOutput(t *testing.T) {
 		})
 	}
 }
-
-func TestContainsProfileLine(t *testing.T) {
-	exact := "export PATH=$PATH:/usr/local/go/bin"
-
-	tests := []struct {
-		name    string
-		content string
-		want    bool
-	}{
-		{"ExactLine", exact + "\n", true},
-		{"WhitespaceVariation", "  \t" + exact + "  \n", true},
-		{"AlternateOrder", "export PATH=/usr/local/go/bin:$PATH\n", true},
-		{"MultipleLines", "# comment\nSOME=VAR\n" + exact + "\n", true},
-		{"NotPresent", "# nothing relevant\nexport PATH=$PATH:/usr/local/bin\n", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := containsProfileLine(tt.content, exact)
-			if got != tt.want {
-				t.Errorf("containsProfileLine(%q, %q) = %v; want %v", tt.content, exact, got, tt.want)
-			}
-		})
-	}
-}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// releaseFile describes a single downloadable artifact from the go.dev
+// release manifest (https://go.dev/dl/?mode=json&include=all). It is kept
+// deliberately generic so other subsystems (version listing, the
+// multi-version SDK manager) can reuse it without depending on the
+// verification logic in this file.
+type releaseFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+// release is a single entry of the go.dev JSON manifest: one Go version
+// and all of the platform/kind combinations published for it.
+type release struct {
+	Version string        `json:"version"`
+	Stable  bool          `json:"stable"`
+	Files   []releaseFile `json:"files"`
+}
+
+const manifestURL = "https://go.dev/dl/?mode=json&include=all"
+
+// fetchReleaseManifest downloads and parses the full go.dev release manifest.
+func fetchReleaseManifest() ([]release, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "go-updater/1.0 (https://github.com/L1ghtn1ng/go-updater)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, manifestURL)
+	}
+
+	var releases []release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("decode release manifest: %w", err)
+	}
+	return releases, nil
+}
+
+// findReleaseFile locates the manifest entry matching version/goos/goarch/kind
+// (kind is typically "archive"). It returns an error naming the version if
+// no matching entry is found, since that usually means a typo'd or
+// unpublished version string.
+func findReleaseFile(releases []release, version, goos, goarch, kind string) (*releaseFile, error) {
+	for _, r := range releases {
+		if r.Version != version {
+			continue
+		}
+		for i := range r.Files {
+			f := r.Files[i]
+			if f.OS == goos && f.Arch == goarch && f.Kind == kind {
+				return &f, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no %s/%s %s found for version %s in release manifest", goos, goarch, kind, version)
+}
+
+// archiveDownloadURL builds the go.dev download URL for a manifest filename.
+func archiveDownloadURL(filename string) string {
+	return "https://go.dev/dl/" + filename
+}
+
+// resolveArchive ensures the archive for version/goos/goarch exists at
+// tarPath (downloading from url if needed) and verifies it against the
+// go.dev release manifest's sha256 and size fields.
+func resolveArchive(version, goos, goarch, tarPath, url string, quiet bool) error {
+	releases, err := fetchReleaseManifest()
+	if err != nil {
+		return fmt.Errorf("fetch release manifest: %w", err)
+	}
+	rf, err := findReleaseFile(releases, version, goos, goarch, "archive")
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(tarPath); err == nil {
+		log("Using existing archive: %s", tarPath)
+	} else {
+		opts := downloadOptions{expectedSHA256: rf.SHA256, quiet: quiet}
+		if err := downloadFile(url, tarPath, opts); err != nil {
+			return fmt.Errorf("download archive: %w", err)
+		}
+		log("Downloaded: %s", tarPath)
+	}
+
+	if err := verifyArchive(tarPath, rf); err != nil {
+		return err
+	}
+	log("Verified sha256 and size against release manifest")
+	return nil
+}
+
+// verifyArchive checks a downloaded archive against the size and sha256
+// fields from the release manifest. On mismatch it removes the bad file so a
+// retry doesn't mistake it for a good cached download.
+func verifyArchive(path string, rf *releaseFile) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if rf.Size > 0 && fi.Size() != rf.Size {
+		os.Remove(path)
+		return fmt.Errorf("size mismatch for %s: got %d bytes, manifest says %d", path, fi.Size(), rf.Size)
+	}
+
+	sum, err := sha256File(path)
+	if err != nil {
+		return err
+	}
+	if sum != rf.SHA256 {
+		os.Remove(path)
+		return fmt.Errorf("sha256 mismatch for %s: got %s, manifest says %s", path, sum, rf.SHA256)
+	}
+	return nil
+}
+
+// verifySignature downloads the detached ".asc" signature for an archive and
+// verifies it with gpg against the bundled Go release signing key. It
+// requires a working "gpg" binary on PATH.
+func verifySignature(archivePath, archiveURL string) error {
+	ascPath := archivePath + ".asc"
+	if err := downloadFile(archiveURL+".asc", ascPath, downloadOptions{quiet: true}); err != nil {
+		return fmt.Errorf("download signature: %w", err)
+	}
+	defer os.Remove(ascPath)
+
+	keyring, err := importGoReleaseKey()
+	if err != nil {
+		return fmt.Errorf("import Go release signing key: %w", err)
+	}
+	defer os.Remove(keyring)
+
+	out, err := exec.Command("gpg", "--no-default-keyring", "--keyring", keyring, "--verify", ascPath, archivePath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gpg --verify failed: %w\nOutput: %s", err, string(out))
+	}
+	return nil
+}
+
+// ensureReleaseKeyIsGenuine fails --verify-signature immediately, before any
+// archive is downloaded, if the bundled key isn't actually
+// goReleaseKeyFingerprint. Shipping a flag that advertises signature
+// verification but is wired to a placeholder key that can never match
+// would silently never succeed for a real user; refuse up front instead,
+// with an error that says why.
+func ensureReleaseKeyIsGenuine() error {
+	keyring, err := importGoReleaseKey()
+	if err != nil {
+		return fmt.Errorf("bundled release key is not usable: %w", err)
+	}
+	os.Remove(keyring)
+	return nil
+}
+
+// goReleaseKeyFingerprint is the fingerprint of the Go distribution signing
+// key, kept here so callers can sanity-check imported keys against it.
+const goReleaseKeyFingerprint = "EB4C 1BFD 4F04 2F6D DDCC  EC91 7721 F63B D38B 4796"
+
+// importGoReleaseKey writes the bundled Go release signing key to a
+// temporary keyring and returns its path. Keeping the key bundled (rather
+// than fetched from a keyserver at verification time) avoids trusting the
+// network for the one step whose entire purpose is not trusting the network.
+func importGoReleaseKey() (string, error) {
+	tmp, err := os.CreateTemp("", "go-updater-keyring-*.gpg")
+	if err != nil {
+		return "", err
+	}
+	path := tmp.Name()
+	tmp.Close()
+	os.Remove(path) // gpg wants to create the keyring itself
+
+	cmd := exec.Command("gpg", "--no-default-keyring", "--keyring", path, "--import")
+	cmd.Stdin = strings.NewReader(goReleaseSigningKeyASC)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%w\nOutput: %s", err, string(out))
+	}
+
+	if err := checkKeyFingerprint(path); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// checkKeyFingerprint confirms the key just imported into keyring is
+// actually goReleaseKeyFingerprint, so a corrupted or swapped-in bundled
+// key fails --verify-signature loudly here instead of silently verifying
+// archives against the wrong signer.
+func checkKeyFingerprint(keyring string) error {
+	out, err := exec.Command("gpg", "--no-default-keyring", "--keyring", keyring, "--with-colons", "--fingerprint").Output()
+	if err != nil {
+		return fmt.Errorf("inspect imported key: %w", err)
+	}
+	want := strings.ReplaceAll(goReleaseKeyFingerprint, " ", "")
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) > 9 && fields[0] == "fpr" && fields[9] == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("bundled release key does not match expected fingerprint %s (see release_key.go)", goReleaseKeyFingerprint)
+}
+
+// sha256File returns the lowercase hex-encoded sha256 digest of path.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
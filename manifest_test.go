@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestFindReleaseFile(t *testing.T) {
+	releases := []release{
+		{
+			Version: "go1.22.6",
+			Stable:  true,
+			Files: []releaseFile{
+				{Filename: "go1.22.6.linux-amd64.tar.gz", OS: "linux", Arch: "amd64", Version: "go1.22.6", SHA256: "abc123", Size: 100, Kind: "archive"},
+				{Filename: "go1.22.6.src.tar.gz", OS: "", Arch: "", Version: "go1.22.6", SHA256: "def456", Size: 200, Kind: "source"},
+			},
+		},
+	}
+
+	rf, err := findReleaseFile(releases, "go1.22.6", "linux", "amd64", "archive")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rf.SHA256 != "abc123" {
+		t.Errorf("got sha256 %q; want %q", rf.SHA256, "abc123")
+	}
+
+	if _, err := findReleaseFile(releases, "go1.99.0", "linux", "amd64", "archive"); err == nil {
+		t.Error("expected error for unknown version, got nil")
+	}
+	if _, err := findReleaseFile(releases, "go1.22.6", "windows", "amd64", "archive"); err == nil {
+		t.Error("expected error for unknown platform, got nil")
+	}
+}
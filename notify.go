@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"time"
+)
+
+// versionUpdate describes a newly detected release. It's passed to every
+// configured notifier and, for webhookNotifier, marshaled directly as the
+// POST body.
+type versionUpdate struct {
+	Old          string `json:"old"`
+	New          string `json:"new"`
+	ChangelogURL string `json:"changelog_url"`
+}
+
+// notifier delivers a versionUpdate through some channel. --watch sends
+// each update to every configured notifier; one failing doesn't stop the
+// rest.
+type notifier interface {
+	notify(update versionUpdate) error
+}
+
+// stdoutNotifier logs the update via the repo's standard log() helper.
+// It's always included under --watch, regardless of what else is
+// configured.
+type stdoutNotifier struct{}
+
+func (stdoutNotifier) notify(u versionUpdate) error {
+	log("New Go release available: %s -> %s (%s)", u.Old, u.New, u.ChangelogURL)
+	return nil
+}
+
+// webhookNotifier POSTs the update as JSON to a configured URL.
+type webhookNotifier struct{ url string }
+
+func (w webhookNotifier) notify(u versionUpdate) error {
+	body, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook POST %s: %w", w.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook POST %s: HTTP %d", w.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// notifySendNotifier shells out to 'notify-send', the desktop-notification
+// tool most Linux desktop environments ship. Like gpg in manifest.go, it's
+// an optional external tool whose absence is just a notify() error, not a
+// build tag.
+type notifySendNotifier struct{}
+
+func (notifySendNotifier) notify(u versionUpdate) error {
+	title := "Go update available"
+	body := fmt.Sprintf("%s -> %s", u.Old, u.New)
+	if out, err := exec.Command("notify-send", title, body).CombinedOutput(); err != nil {
+		return fmt.Errorf("notify-send: %w\nOutput: %s", err, string(out))
+	}
+	return nil
+}
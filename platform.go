@@ -0,0 +1,63 @@
+package main
+
+import (
+	"runtime"
+)
+
+// platform captures the OS-specific pieces of installing Go: the default
+// install location, archive extraction, PATH management, and how (or
+// whether) privilege escalation works. main and the Installer
+// implementations talk only to this interface, so none of them hard-code
+// tar, /usr/local/go, or sudo directly.
+type platform interface {
+	// installRoot is the default location Go is extracted into, e.g.
+	// "/usr/local/go" or `%LOCALAPPDATA%\Programs\Go`.
+	installRoot() string
+	// archiveExt is the file extension go.dev publishes archives in for
+	// this OS: "tar.gz" everywhere except Windows, which uses "zip".
+	archiveExt() string
+	// removeInstall removes a previous install at root, if any.
+	removeInstall(root string) error
+	// extract unpacks archivePath (a .tar.gz or .zip, per archiveExt) into
+	// destDir, stripping the top-level 'go/' directory the way
+	// 'tar --strip-components=1' does. Assumes destDir is already writable
+	// by the current user.
+	extract(archivePath, destDir string) error
+	// installPrivileged is like extract, but escalates privileges first if
+	// destDir needs it (e.g. /usr/local on Unix). On platforms whose
+	// installRoot never needs elevation (Windows), it's equivalent to
+	// extract.
+	installPrivileged(archivePath, destDir string) error
+	// renameDir moves a fully-extracted version directory into place,
+	// escalating privileges if the destination requires it.
+	renameDir(oldPath, newPath string) error
+	// activate makes target the active install at root (a symlink swap on
+	// Unix; a direct directory replace on Windows, which doesn't keep
+	// previous versions around for rollback).
+	activate(root, target string) error
+	// activeTarget returns the version-specific directory root currently
+	// resolves to, or "" if none (first install, or a platform that
+	// doesn't track one).
+	activeTarget(root string) string
+	// ensureUserPath makes sure <installRoot>/bin is on the current user's
+	// PATH for future shells/sessions.
+	ensureUserPath(installRoot string) error
+	// ensureSystemPath makes <installRoot>/bin available to every user.
+	ensureSystemPath(installRoot string) error
+	// runPrivileged runs cmd with the privileges needed to write to
+	// installRoot (root via sudo on Unix; a plain exec on Windows, since
+	// %LOCALAPPDATA% doesn't require elevation).
+	runPrivileged(cmd string, args ...string) error
+}
+
+// currentPlatform (in platform_select_unix.go / platform_select_windows.go,
+// each build-tagged to match the file defining the constructors it calls)
+// returns the platform implementation for runtime.GOOS.
+
+// goBinaryName is the 'go' tool's executable name on the current OS.
+func goBinaryName() string {
+	if runtime.GOOS == "windows" {
+		return "go.exe"
+	}
+	return "go"
+}
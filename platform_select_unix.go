@@ -0,0 +1,22 @@
+//go:build linux || darwin || freebsd
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// currentPlatform returns the platform implementation for runtime.GOOS.
+func currentPlatform() (platform, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return newLinuxPlatform(), nil
+	case "darwin":
+		return newDarwinPlatform(), nil
+	case "freebsd":
+		return newFreebsdPlatform(), nil
+	default:
+		return nil, fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+	}
+}
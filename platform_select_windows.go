@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+// currentPlatform returns the platform implementation for runtime.GOOS.
+func currentPlatform() (platform, error) {
+	return windowsPlatform{}, nil
+}
@@ -0,0 +1,227 @@
+//go:build linux || darwin || freebsd
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// unixPlatform implements the shared parts of the platform interface for
+// linux, darwin, and freebsd: tar.gz extraction and sudo-based privilege
+// escalation. Only the PATH-management details (which profile files to
+// touch, where the system-wide snippet goes) differ per OS.
+type unixPlatform struct {
+	// profileCandidates are checked/appended in order for ensureUserPath.
+	profileCandidates []string
+	// systemPathFile is where ensureSystemPath installs its PATH snippet.
+	systemPathFile string
+	// systemPathContent is rendered into systemPathFile.
+	systemPathContent string
+}
+
+func (unixPlatform) installRoot() string { return "/usr/local/go" }
+func (unixPlatform) archiveExt() string  { return "tar.gz" }
+
+func (unixPlatform) extract(archivePath, destDir string) error {
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return err
+	}
+	if out, err := exec.Command("tar", "-C", destDir, "--strip-components=1", "-xzf", archivePath).CombinedOutput(); err != nil {
+		return fmt.Errorf("tar: %w\nOutput: %s", err, string(out))
+	}
+	return nil
+}
+
+func (unixPlatform) runPrivileged(cmd string, args ...string) error {
+	return runAsRoot(cmd, args...)
+}
+
+func (unixPlatform) removeInstall(root string) error {
+	return runAsRoot("rm", "-rf", root)
+}
+
+// installPrivileged extracts archivePath into destDir as root, since
+// destDir normally lives under /usr/local where the current user can't
+// write directly.
+func (unixPlatform) installPrivileged(archivePath, destDir string) error {
+	cmd := fmt.Sprintf("mkdir -p %s && tar -C %s --strip-components=1 -xzf %s",
+		shellQuote(destDir), shellQuote(destDir), shellQuote(archivePath))
+	if err := runAsRoot("sh", "-c", cmd); err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+	return nil
+}
+
+func (unixPlatform) renameDir(oldPath, newPath string) error {
+	return runAsRoot("mv", oldPath, newPath)
+}
+
+// activate points root at target via an atomic symlink swap ('ln -sfn'
+// replaces the symlink itself in one rename(2), unlike GNU-only 'mv -T').
+// If root is still a plain directory from an older, non-versioned install,
+// it's removed first so the symlink can take its place.
+func (unixPlatform) activate(root, target string) error {
+	if fi, err := os.Lstat(root); err == nil && fi.Mode()&os.ModeSymlink == 0 {
+		if err := runAsRoot("rm", "-rf", root); err != nil {
+			return fmt.Errorf("remove legacy install at %s: %w", root, err)
+		}
+	}
+	return runAsRoot("ln", "-sfn", target, root)
+}
+
+// activeTarget resolves root's symlink, returning "" if root isn't a
+// symlink (no versioned install yet).
+func (unixPlatform) activeTarget(root string) string {
+	dest, err := os.Readlink(root)
+	if err != nil {
+		return ""
+	}
+	if !filepath.IsAbs(dest) {
+		dest = filepath.Join(filepath.Dir(root), dest)
+	}
+	return dest
+}
+
+// shellQuote single-quotes s for safe interpolation into a 'sh -c' string.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (p unixPlatform) ensureUserPath(installRoot string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	bin := installRoot + "/bin"
+	line := fmt.Sprintf("export PATH=$PATH:%s", bin)
+
+	for _, name := range p.profileCandidates {
+		path := filepath.Join(home, name)
+		if data, err := os.ReadFile(path); err == nil {
+			if containsProfileLine(string(data), line, bin) {
+				log("User PATH already contains %s/bin in %s", installRoot, path)
+				return nil
+			}
+		}
+	}
+
+	for _, name := range p.profileCandidates {
+		path := filepath.Join(home, name)
+		if _, err := os.Stat(path); err == nil {
+			return appendProfileLine(path, line)
+		}
+	}
+
+	return appendProfileLine(filepath.Join(home, p.profileCandidates[0]), line)
+}
+
+func appendProfileLine(path, line string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := bufio.NewWriter(f)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "# Added by go-updater to expose Go binaries")
+	fmt.Fprintln(writer, line)
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	log("Added PATH update to %s", path)
+	return nil
+}
+
+// containsProfileLine reports whether content already exports bin onto
+// PATH, either as the exact line go-updater writes or some other ordering
+// (e.g. "export PATH=<bin>:$PATH") a user may have added by hand.
+func containsProfileLine(content, target, bin string) bool {
+	// consider whitespace variations
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == target {
+			return true
+		}
+		if strings.Contains(line, bin) && strings.Contains(line, "export PATH") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p unixPlatform) ensureSystemPath(installRoot string) error {
+	content := fmt.Sprintf(p.systemPathContent, installRoot)
+
+	tmp, err := os.CreateTemp("", "golang-path-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if err := runAsRoot("install", "-m", "0644", tmpPath, p.systemPathFile); err == nil {
+		log("Added system PATH at %s", p.systemPathFile)
+		return nil
+	}
+
+	cmd := fmt.Sprintf("printf '%s' >> %s", strings.ReplaceAll(content, "'", "'\\''"), p.fallbackProfile())
+	if err := runAsRoot("sh", "-c", cmd); err != nil {
+		return fmt.Errorf("failed to update %s or %s: %w", p.systemPathFile, p.fallbackProfile(), err)
+	}
+	log("Appended system PATH to %s", p.fallbackProfile())
+	return nil
+}
+
+func (p unixPlatform) fallbackProfile() string {
+	if strings.Contains(p.systemPathFile, "paths.d") {
+		return "/etc/zprofile"
+	}
+	return "/etc/profile"
+}
+
+// linuxPlatform installs to /usr/local/go and manages PATH via ~/.profile
+// and /etc/profile.d, matching most distributions' login shell conventions.
+type linuxPlatform struct{ unixPlatform }
+
+func newLinuxPlatform() linuxPlatform {
+	return linuxPlatform{unixPlatform{
+		profileCandidates: []string{".profile"},
+		systemPathFile:    "/etc/profile.d/golang-path.sh",
+		systemPathContent: "# /etc/profile.d/golang-path.sh\n# Added by go-updater\nexport PATH=\"$PATH:%s/bin\"\n",
+	}}
+}
+
+// freebsdPlatform mirrors linuxPlatform; FreeBSD's base /bin/sh also reads
+// /etc/profile.d snippets when present.
+type freebsdPlatform struct{ unixPlatform }
+
+func newFreebsdPlatform() freebsdPlatform {
+	return freebsdPlatform{unixPlatform{
+		profileCandidates: []string{".profile"},
+		systemPathFile:    "/etc/profile.d/golang-path.sh",
+		systemPathContent: "# /etc/profile.d/golang-path.sh\n# Added by go-updater\nexport PATH=\"$PATH:%s/bin\"\n",
+	}}
+}
+
+// darwinPlatform manages PATH via the zsh/bash profile files macOS ships
+// and prefers /etc/paths.d, which both bash and zsh read on macOS.
+type darwinPlatform struct{ unixPlatform }
+
+func newDarwinPlatform() darwinPlatform {
+	return darwinPlatform{unixPlatform{
+		profileCandidates: []string{".zprofile", ".zshrc", ".bash_profile", ".profile"},
+		systemPathFile:    "/etc/paths.d/go",
+		systemPathContent: "%s/bin\n",
+	}}
+}
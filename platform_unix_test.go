@@ -0,0 +1,32 @@
+//go:build linux || darwin || freebsd
+
+package main
+
+import "testing"
+
+func TestContainsProfileLine(t *testing.T) {
+	bin := "/usr/local/go/bin"
+	exact := "export PATH=$PATH:" + bin
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"ExactLine", exact + "\n", true},
+		{"WhitespaceVariation", "  \t" + exact + "  \n", true},
+		{"AlternateOrder", "export PATH=" + bin + ":$PATH\n", true},
+		{"MultipleLines", "# comment\nSOME=VAR\n" + exact + "\n", true},
+		{"NotPresent", "# nothing relevant\nexport PATH=$PATH:/usr/local/bin\n", false},
+		{"DifferentRoot", "export PATH=$PATH:/opt/go/bin\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := containsProfileLine(tt.content, exact, bin)
+			if got != tt.want {
+				t.Errorf("containsProfileLine(%q, %q, %q) = %v; want %v", tt.content, exact, bin, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+//go:build windows
+
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// windowsPlatform installs Go into %LOCALAPPDATA%\Programs\Go by default,
+// extracts the .zip archives go.dev publishes for Windows, and manages PATH
+// through the current user's (or machine's) registry Environment key
+// instead of editing shell profile files.
+type windowsPlatform struct{}
+
+func (windowsPlatform) installRoot() string {
+	root := os.Getenv("LOCALAPPDATA")
+	if root == "" {
+		root = filepath.Join(os.Getenv("USERPROFILE"), "AppData", "Local")
+	}
+	return filepath.Join(root, "Programs", "Go")
+}
+
+func (windowsPlatform) archiveExt() string { return "zip" }
+
+// removeInstall doesn't need elevation: %LOCALAPPDATA% is owned by the
+// current user.
+func (windowsPlatform) removeInstall(root string) error {
+	return os.RemoveAll(root)
+}
+
+// runPrivileged is a plain exec on Windows: installs under %LOCALAPPDATA%
+// never need elevation, unlike /usr/local/go on Unix.
+func (windowsPlatform) runPrivileged(cmd string, args ...string) error {
+	execCmd := exec.Command(cmd, args...)
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+	return execCmd.Run()
+}
+
+// installPrivileged needs no elevation on Windows: %LOCALAPPDATA% is owned
+// by the current user, so this is just extract.
+func (windowsPlatform) installPrivileged(archivePath, destDir string) error {
+	return windowsPlatform{}.extract(archivePath, destDir)
+}
+
+// renameDir needs no elevation either; os.Rename is atomic on the same
+// volume, same as 'ln -sfn' is on Unix.
+func (windowsPlatform) renameDir(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// activate replaces root's contents with target's outright. Unlike Unix,
+// installRoot here never needs elevation, so there's no reason to keep a
+// separate versioned directory around for a symlink to point at; this
+// trades away 'go-updater rollback' support on Windows for a simpler,
+// privilege-free install path.
+func (windowsPlatform) activate(root, target string) error {
+	if root != target {
+		if err := os.RemoveAll(root); err != nil {
+			return err
+		}
+		if err := os.Rename(target, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// activeTarget always reports unknown: activate consumes target into root,
+// so there's never a separate versioned directory left to roll back to.
+func (windowsPlatform) activeTarget(root string) string { return "" }
+
+func (windowsPlatform) extract(archivePath, destDir string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, f := range r.File {
+		// Entries are rooted at 'go/'; strip it, the same way
+		// 'tar --strip-components=1' does for the Unix archives.
+		name := strings.TrimPrefix(filepath.ToSlash(f.Name), "go/")
+		if name == "" {
+			continue
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(name))
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, f.Mode()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := extractZipEntry(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipEntry(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
+
+func (windowsPlatform) ensureUserPath(installRoot string) error {
+	return updateRegistryPath(`HKCU\Environment`, filepath.Join(installRoot, "bin"))
+}
+
+func (windowsPlatform) ensureSystemPath(installRoot string) error {
+	return updateRegistryPath(`HKLM\SYSTEM\CurrentControlSet\Control\Session Manager\Environment`, filepath.Join(installRoot, "bin"))
+}
+
+// updateRegistryPath appends bin to the Path value under key (HKCU or HKLM
+// Environment), then broadcasts WM_SETTINGCHANGE so running processes pick
+// it up without a reboot.
+func updateRegistryPath(key, bin string) error {
+	out, _ := exec.Command("reg", "query", key, "/v", "Path").CombinedOutput()
+	existing := registryPathValue(string(out))
+	if strings.Contains(existing, bin) {
+		log("PATH already contains %s under %s", bin, key)
+		return nil
+	}
+
+	newValue := bin
+	if existing != "" {
+		newValue = existing + ";" + bin
+	}
+	if out, err := exec.Command("reg", "add", key, "/v", "Path", "/t", "REG_EXPAND_SZ", "/d", newValue, "/f").CombinedOutput(); err != nil {
+		return fmt.Errorf("reg add %s: %w\nOutput: %s", key, err, string(out))
+	}
+
+	broadcastEnvironmentChange()
+	log("Added %s to PATH under %s", bin, key)
+	return nil
+}
+
+// registryPathValue extracts the value portion from 'reg query' output:
+//
+//	HKEY_CURRENT_USER\Environment
+//	    Path    REG_EXPAND_SZ    C:\Existing;C:\Path
+func registryPathValue(regQueryOutput string) string {
+	for _, line := range strings.Split(regQueryOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Path") {
+			continue
+		}
+		for _, kind := range []string{"REG_EXPAND_SZ", "REG_SZ"} {
+			if parts := strings.SplitN(line, kind, 2); len(parts) == 2 {
+				return strings.TrimSpace(parts[1])
+			}
+		}
+	}
+	return ""
+}
+
+// broadcastEnvironmentChange notifies running processes (Explorer, open
+// shells) that the environment changed, the same way the Windows installer
+// and 'setx' do, so a new PATH is visible without signing out.
+func broadcastEnvironmentChange() {
+	const script = `
+$sig = '[DllImport("user32.dll", SetLastError = true, CharSet = CharSet.Auto)] public static extern IntPtr SendMessageTimeout(IntPtr hWnd, uint Msg, UIntPtr wParam, string lParam, uint fuFlags, uint uTimeout, out UIntPtr lpdwResult);'
+$type = Add-Type -MemberDefinition $sig -Name Win32SendMessageTimeout -Namespace Win32Functions -PassThru
+$result = [UIntPtr]::Zero
+$type::SendMessageTimeout([IntPtr]0xffff, 0x1a, [UIntPtr]::Zero, "Environment", 2, 5000, [ref]$result) | Out-Null
+`
+	if err := exec.Command("powershell", "-NoProfile", "-Command", script).Run(); err != nil {
+		warn("broadcast environment change failed: %v", err)
+	}
+}
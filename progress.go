@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// progressBar renders a "downloaded/total percentage throughput ETA" line
+// to stderr, redrawn in place with a carriage return. It implements
+// io.Writer so it can sit directly in an io.MultiWriter alongside the
+// destination file and hasher in attemptDownload. It's a no-op when quiet is
+// set or stderr isn't a terminal, so piped or logged output stays clean.
+type progressBar struct {
+	total     int64
+	done      int64
+	start     time.Time
+	lastPrint time.Time
+	enabled   bool
+}
+
+func newProgressBar(total, alreadyDone int64, quiet bool) *progressBar {
+	return &progressBar{
+		total:   total,
+		done:    alreadyDone,
+		start:   time.Now(),
+		enabled: !quiet && isTerminal(os.Stderr),
+	}
+}
+
+func (b *progressBar) Write(p []byte) (int, error) {
+	b.done += int64(len(p))
+	if b.enabled && time.Since(b.lastPrint) > 200*time.Millisecond {
+		b.render()
+		b.lastPrint = time.Now()
+	}
+	return len(p), nil
+}
+
+// finish prints one last, up-to-date render and moves to a new line so
+// later log output doesn't overwrite the final progress line.
+func (b *progressBar) finish() {
+	if !b.enabled {
+		return
+	}
+	b.render()
+	fmt.Fprintln(os.Stderr)
+}
+
+func (b *progressBar) render() {
+	elapsed := time.Since(b.start).Seconds()
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(b.done) / elapsed
+	}
+
+	if b.total > 0 {
+		pct := float64(b.done) / float64(b.total) * 100
+		var eta time.Duration
+		if rate > 0 {
+			eta = (time.Duration(float64(b.total-b.done)/rate) * time.Second).Round(time.Second)
+		}
+		fmt.Fprintf(os.Stderr, "\r%s / %s (%.1f%%) %s/s ETA %s   ",
+			formatBytes(b.done), formatBytes(b.total), pct, formatBytes(int64(rate)), eta)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r%s downloaded, %s/s   ", formatBytes(b.done), formatBytes(int64(rate)))
+}
+
+// formatBytes renders n bytes as a short human-readable size, e.g. "42.3MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,45 @@
+package main
+
+// goReleaseSigningKeyASC is meant to be the ASCII-armored public key used to
+// sign official Go release archives (fingerprint goReleaseKeyFingerprint,
+// see manifest.go). This build environment has no network egress to pull
+// the real key from a trusted source, so the block below is a locally
+// generated stand-in: it is a syntactically valid, importable PGP key (so
+// --verify-signature fails with a clear "key does not match" error instead
+// of a gpg import crash), but it is NOT the actual Go distribution key and
+// its fingerprint does not match goReleaseKeyFingerprint. importGoReleaseKey
+// checks the fingerprint after import for exactly this reason. Before this
+// feature is used against real go.dev releases, replace this block with the
+// genuine key (fingerprint EB4C 1BFD 4F04 2F6D DDCC EC91 7721 F63B D38B
+// 4796), e.g. from `gpg --keyserver keys.openpgp.org --recv-keys
+// EB4C1BFD4F042F6DDDCCEC917721F63BD38B4796` on a host with network access.
+const goReleaseSigningKeyASC = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mQINBGplnaMBEADPnAtoVzE/2f+UaI9rprM0aWUz1UXRJ39o7B/vdQ6n+8Hgf7Mb
+xfdHzcbXH5HEXHJVA8NhXSa4a/7xDsdCTlgfL4NcptoLWzKSSzyQTyGqB31kS8ui
+zn01EATHStvx+M0BVyMFZo2HQG8eYu37cOWkmoj5D782pyM6+6TdGEtXH6lgMZTY
++jr6EuHqB3Ph4GhsU44PMYJbL6pylP7jzO0QZ73ofbLYv7Af5e69spbkFdy+VTFJ
+saSq9AFd3r61LdyTA2qVQ1rsvm5TZUJUn4NYsmXzud5DodnabV7Nsa9mmwc6PLRc
+nZxsl2asFan7LurxNpJ9WD0NXLcXk9vQnnDNYOrqAcoQ/lu6YxSfpDuzzBPXwSR5
+v0+MB2y+PwLvt8N6QF+DjiAQuumpIi2xH03zu8qc8cMI8c11KiP9AvIFv3FZWFHN
+GgEjhsD02WhYHOijAcSQYvU70BRXp50UCi7XzxbnSJbtJq7d/J7uFt9HYXuDydYF
+5ExPyfgD7pYjGEVFO+YyxqOBIyefQ98I5XkNjHcH6NOinu8IjhJi0WwjZ+EY35/c
+er3MvTLQSUcek11to5dilCBAWs9MEsTP82mh91DFN+nh9cCE+Yj5DbXl4rap6W/A
+fzTl5JgkMu7c2HFXmajpPfOZ7B2Y0z/28njWio+C8vQRFxQ5XjlugBfvXQARAQAB
+tDJHbyBEaXN0cmlidXRpb24gPGdvbGFuZy1hbm5vdW5jZUBnb29nbGVncm91cHMu
+Y29tPokCTgQTAQoAOBYhBIWzh+ae02EHPOY12GstL813E+ouBQJqZZ2jAhsDBQsJ
+CAcCBhUKCQgLAgQWAgMBAh4BAheAAAoJEGstL813E+ougYsQAIArjE1huliu0srY
+M/xw5NV6V9CaXziKj1DdfR10blU4Zn0HPlNa5ffBxmaV/UZ/NYSkEwWKNQyjwjfo
+6+FxBUnJaTZ0eoflqdyfkxtAlI+wFu5tIkK8x+Ot+Bxk+5GiIc5V3kxxaHOMBvAK
+b489Nm2bb4dqX1yzmZVWmXKzv+3LFkR2am2DV47u1ELV2yVKqQHeFayn00CpjWNr
+j11CuylKIigafLvt3wrPOX55Rx3UIgMoVPnaYey/aDLw02RQSkSw9UNxLVDYWp46
+CAHNUWcVNFWg4DprEukjkRENbG9G7h+9k4h6ElmxlqT+Ki31SKHT3LrRa0puSjVr
+iNcIE4OyX3+ObfQqH7GOpRLjZYzSIPLt3OWrBv7/j+AybpXOFLKDViVJ1c/hI/DV
+IeNW83HyGwCOCIHmkA+JRFlFzlfs16Q4p7ZrNz/BwpEVmI2/UQUj/JEXTjCmjLv/
+jayQbEgz1FEINwYFFmtIsmCe+lvuk09ms4lo+sMEb8G7G25mg3wd1Xazhk/bqPOB
+AP66eonVrr24iiXm/Y9EheFGzTtrIHp/8x63dJY+xsI+Z05rkd3RLGQEXpCy3g+K
+PjaDRr5BYCuGAqbNR/ZtgqrcYCVZr4kXEzPunvMotJN/O9MOk3tV+LyEZHdL7SIx
+oB4bnkHly3ya5ZWSGpKrKzECKUcJ
+=BcBZ
+-----END PGP PUBLIC KEY BLOCK-----
+`
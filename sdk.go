@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Installer installs, activates, and removes Go toolchains. systemInstaller
+// implements the original single-slot /usr/local/go flow; sdkInstaller
+// implements the golang.org/dl-style side-by-side ~/sdk flow added by the
+// 'install'/'list'/'use'/'uninstall' subcommands.
+type Installer interface {
+	// Install unpacks the archive at tarPath for version, returning the
+	// resulting GOROOT.
+	Install(version, tarPath string) (goroot string, err error)
+	// Use activates version as the active 'go' toolchain.
+	Use(version string) error
+	// Uninstall removes a previously installed version.
+	Uninstall(version string) error
+	// Installed lists versions currently installed by this installer.
+	Installed() ([]string, error)
+}
+
+// systemInstaller manages the /usr/local/go installation go-updater has
+// always driven when invoked with no subcommand. Install, Rollback, and GC
+// (in atomic_install.go) keep it atomic: installRoot() is a symlink to a
+// versioned sibling directory rather than a directory extracted into in
+// place.
+type systemInstaller struct{}
+
+func (systemInstaller) Use(version string) error {
+	installed, err := getInstalledGoVersion()
+	if err != nil {
+		return err
+	}
+	if installed != version {
+		plat, err := currentPlatform()
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("system installer only manages one slot; %s is %s, not %s (run without a subcommand to install it there)", plat.installRoot(), installed, version)
+	}
+	return nil
+}
+
+func (systemInstaller) Uninstall(version string) error {
+	plat, err := currentPlatform()
+	if err != nil {
+		return err
+	}
+	return plat.removeInstall(plat.installRoot())
+}
+
+func (systemInstaller) Installed() ([]string, error) {
+	v, err := getInstalledGoVersion()
+	if err != nil {
+		return nil, nil
+	}
+	return []string{v}, nil
+}
+
+// sdkInstaller installs Go toolchains side-by-side under ~/sdk/<version>, in
+// the layout golang.org/dl/go1.X manages. Install and Uninstall never touch
+// /usr/local/go; Use repoints the platform's install root at an SDK version.
+type sdkInstaller struct{}
+
+func sdkRoot() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "sdk"), nil
+}
+
+// gobinDir returns $GOBIN if set, otherwise ~/go/bin, matching where 'go
+// install' puts built binaries.
+func gobinDir() (string, error) {
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		return gobin, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "go", "bin"), nil
+}
+
+func (sdkInstaller) dir(version string) (string, error) {
+	root, err := sdkRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, version), nil
+}
+
+func (s sdkInstaller) Install(version, tarPath string) (string, error) {
+	dir, err := s.dir(version)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bin", goBinaryName())); err == nil {
+		log("%s is already installed at %s", version, dir)
+	} else {
+		plat, err := currentPlatform()
+		if err != nil {
+			return "", err
+		}
+		if err := plat.extract(tarPath, dir); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("extract archive to %s: %w", dir, err)
+		}
+	}
+
+	if err := s.writeWrapper(version, dir); err != nil {
+		return "", fmt.Errorf("write wrapper binary: %w", err)
+	}
+	return dir, nil
+}
+
+// writeWrapper creates a thin wrapper script in GOBIN named after version
+// (e.g. 'go1.22.6') that execs the real 'go' binary inside dir with GOROOT
+// set, forwarding all args and stdio.
+func (sdkInstaller) writeWrapper(version, dir string) error {
+	bin, err := gobinDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(bin, 0o755); err != nil {
+		return err
+	}
+
+	wrapper := fmt.Sprintf("#!/bin/sh\nexec env GOROOT=%q %q \"$@\"\n", dir, filepath.Join(dir, "bin", goBinaryName()))
+	path := filepath.Join(bin, version)
+	if err := os.WriteFile(path, []byte(wrapper), 0o755); err != nil {
+		return err
+	}
+	log("Wrapper installed at %s", path)
+	return nil
+}
+
+func (s sdkInstaller) Use(version string) error {
+	dir, err := s.dir(version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bin", goBinaryName())); err != nil {
+		return fmt.Errorf("%s is not installed (run 'go-updater install %s' first)", version, version)
+	}
+	plat, err := currentPlatform()
+	if err != nil {
+		return err
+	}
+	root := plat.installRoot()
+	if err := plat.activate(root, dir); err != nil {
+		return fmt.Errorf("repoint %s: %w", root, err)
+	}
+	log("%s now points at %s", root, dir)
+	return nil
+}
+
+func (s sdkInstaller) Uninstall(version string) error {
+	dir, err := s.dir(version)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if bin, err := gobinDir(); err == nil {
+		os.Remove(filepath.Join(bin, version))
+	}
+	log("Removed %s", dir)
+	return nil
+}
+
+func (sdkInstaller) Installed() ([]string, error) {
+	root, err := sdkRoot()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, e := range entries {
+		if e.IsDir() {
+			if _, err := os.Stat(filepath.Join(root, e.Name(), "bin", goBinaryName())); err == nil {
+				versions = append(versions, e.Name())
+			}
+		}
+	}
+	return versions, nil
+}
+
+// runSDKCommand dispatches the 'install', 'list', 'use', 'uninstall',
+// 'rollback', and 'gc' subcommands against the sdkInstaller (rollback and
+// gc act on the system installer instead; see their cases below).
+func runSDKCommand(args []string, quiet bool) error {
+	cmd, rest := args[0], args[1:]
+	inst := sdkInstaller{}
+
+	switch cmd {
+	case "install":
+		if len(rest) != 1 {
+			return errors.New("usage: go-updater install <version>")
+		}
+		version := cleanVersionInput(rest[0])
+		goos, goarch, err := resolveTarget()
+		if err != nil {
+			return err
+		}
+		plat, err := currentPlatform()
+		if err != nil {
+			return err
+		}
+		dlDir := os.TempDir()
+		tarPath := filepath.Join(dlDir, fmt.Sprintf("%s.%s-%s.%s", version, goos, goarch, plat.archiveExt()))
+		url := archiveDownloadURL(filepath.Base(tarPath))
+		if err := resolveArchive(version, goos, goarch, tarPath, url, quiet); err != nil {
+			return err
+		}
+		goroot, err := inst.Install(version, tarPath)
+		if err != nil {
+			return err
+		}
+		log("Installed %s to %s", version, goroot)
+		return nil
+
+	case "list":
+		return listVersions(inst)
+
+	case "use":
+		if len(rest) != 1 {
+			return errors.New("usage: go-updater use <version>")
+		}
+		return inst.Use(cleanVersionInput(rest[0]))
+
+	case "uninstall":
+		if len(rest) != 1 {
+			return errors.New("usage: go-updater uninstall <version>")
+		}
+		return inst.Uninstall(cleanVersionInput(rest[0]))
+
+	case "rollback":
+		if len(rest) != 0 {
+			return errors.New("usage: go-updater rollback")
+		}
+		return systemInstaller{}.Rollback()
+
+	case "gc":
+		keep := maxKeptVersions
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil || n < 0 {
+				return fmt.Errorf("usage: go-updater gc [keep] (got %q)", rest[0])
+			}
+			keep = n
+		} else if len(rest) > 1 {
+			return errors.New("usage: go-updater gc [keep]")
+		}
+		return systemInstaller{}.GC(keep)
+
+	default:
+		return fmt.Errorf("unknown subcommand %q (expected install, list, use, uninstall, rollback, or gc)", cmd)
+	}
+}
+
+func listVersions(inst Installer) error {
+	installed, err := inst.Installed()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Installed:")
+	for _, v := range installed {
+		fmt.Printf("  %s\n", v)
+	}
+	if len(installed) == 0 {
+		fmt.Println("  (none)")
+	}
+
+	releases, err := fetchReleaseManifest()
+	if err != nil {
+		return fmt.Errorf("fetch available versions: %w", err)
+	}
+	fmt.Println("Available (stable):")
+	for _, r := range releases {
+		if r.Stable {
+			fmt.Printf("  %s\n", r.Version)
+		}
+	}
+	return nil
+}
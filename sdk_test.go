@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestSDKInstallerInstalled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	mustMkdirAll := func(path string) {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatalf("MkdirAll(%q): %v", path, err)
+		}
+	}
+
+	// go1.22.6 looks installed (has bin/go); go1.23.0 is just an empty dir
+	// and should not be reported as installed.
+	mustMkdirAll(filepath.Join(home, "sdk", "go1.22.6", "bin"))
+	if err := os.WriteFile(filepath.Join(home, "sdk", "go1.22.6", "bin", "go"), []byte(""), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	mustMkdirAll(filepath.Join(home, "sdk", "go1.23.0"))
+
+	got, err := (sdkInstaller{}).Installed()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(got)
+	want := []string{"go1.22.6"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("Installed() = %v; want %v", got, want)
+	}
+}
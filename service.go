@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// installService writes (and, where possible, enables) a background
+// service that runs 'go-updater' with watchArgs (expected to include
+// --watch) continuously: a systemd user unit on Linux, a launchd agent on
+// macOS. Windows isn't covered; Task Scheduler has no equivalent of a
+// simple "generate and enable a unit file" flow worth hand-rolling here.
+func installService(watchArgs []string) error {
+	switch runtime.GOOS {
+	case "linux":
+		return installSystemdUnit(watchArgs)
+	case "darwin":
+		return installLaunchdAgent(watchArgs)
+	default:
+		return fmt.Errorf("--install-service is not supported on %s", runtime.GOOS)
+	}
+}
+
+func installSystemdUnit(watchArgs []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate go-updater binary: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	unitDir := filepath.Join(home, ".config", "systemd", "user")
+	if err := os.MkdirAll(unitDir, 0o755); err != nil {
+		return err
+	}
+
+	unitPath := filepath.Join(unitDir, "go-updater.service")
+	unit := fmt.Sprintf(`[Unit]
+Description=go-updater watch mode
+
+[Service]
+ExecStart=%s %s
+Restart=on-failure
+
+[Install]
+WantedBy=default.target
+`, exe, strings.Join(watchArgs, " "))
+
+	if err := os.WriteFile(unitPath, []byte(unit), 0o644); err != nil {
+		return err
+	}
+	log("Wrote systemd user unit: %s", unitPath)
+
+	if out, err := exec.Command("systemctl", "--user", "daemon-reload").CombinedOutput(); err != nil {
+		warn("systemctl --user daemon-reload: %v\nOutput: %s", err, string(out))
+	}
+	if out, err := exec.Command("systemctl", "--user", "enable", "--now", "go-updater.service").CombinedOutput(); err != nil {
+		warn("systemctl --user enable --now: %v\nOutput: %s", err, string(out))
+	} else {
+		log("Enabled and started go-updater.service")
+	}
+	return nil
+}
+
+func installLaunchdAgent(watchArgs []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate go-updater binary: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	agentsDir := filepath.Join(home, "Library", "LaunchAgents")
+	if err := os.MkdirAll(agentsDir, 0o755); err != nil {
+		return err
+	}
+
+	const label = "com.l1ghtn1ng.go-updater"
+	plistPath := filepath.Join(agentsDir, label+".plist")
+
+	if err := os.WriteFile(plistPath, []byte(launchdPlist(label, append([]string{exe}, watchArgs...))), 0o644); err != nil {
+		return err
+	}
+	log("Wrote launchd agent: %s", plistPath)
+
+	if out, err := exec.Command("launchctl", "load", "-w", plistPath).CombinedOutput(); err != nil {
+		warn("launchctl load -w: %v\nOutput: %s", err, string(out))
+	} else {
+		log("Loaded %s via launchctl", label)
+	}
+	return nil
+}
+
+// plistEscaper escapes the characters that are special in plist (XML) text
+// content. strings.NewReplacer makes a single pass over its input, so the
+// '&' inside the escapes produced for '<' and '>' is never escaped again.
+var plistEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// launchdPlist renders a minimal launchd agent plist that runs args at
+// login and restarts it if it exits.
+func launchdPlist(label string, args []string) string {
+	var programArgs strings.Builder
+	for _, a := range args {
+		fmt.Fprintf(&programArgs, "\t\t<string>%s</string>\n", plistEscaper.Replace(a))
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+</dict>
+</plist>
+`, plistEscaper.Replace(label), programArgs.String())
+}
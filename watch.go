@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// autoInstallPolicy controls whether --watch installs a newly detected
+// release automatically, and how far it's allowed to jump.
+type autoInstallPolicy string
+
+const (
+	autoInstallNone  autoInstallPolicy = "none"
+	autoInstallPatch autoInstallPolicy = "patch"
+	autoInstallMinor autoInstallPolicy = "minor"
+)
+
+// parseAutoInstallPolicy validates a --auto-install flag value.
+func parseAutoInstallPolicy(s string) (autoInstallPolicy, error) {
+	switch p := autoInstallPolicy(s); p {
+	case autoInstallNone, autoInstallPatch, autoInstallMinor:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid --auto-install value %q (want patch, minor, or none)", s)
+	}
+}
+
+// allows reports whether p permits upgrading from current to candidate.
+// autoInstallPatch only accepts z-level bumps within the currently
+// installed minor version; autoInstallMinor also accepts new minors within
+// the same major version. A missing current version (nothing installed
+// yet) is never auto-installed; run go-updater directly for the first
+// install.
+func (p autoInstallPolicy) allows(current, candidate string) (bool, error) {
+	if p == autoInstallNone || current == "" || current == candidate {
+		return false, nil
+	}
+	cMaj, cMin, cPatch, err := goVersionComponents(current)
+	if err != nil {
+		return false, err
+	}
+	nMaj, nMin, nPatch, err := goVersionComponents(candidate)
+	if err != nil {
+		return false, err
+	}
+	if nMaj != cMaj {
+		return false, nil
+	}
+	switch p {
+	case autoInstallPatch:
+		return nMin == cMin && nPatch > cPatch, nil
+	case autoInstallMinor:
+		return nMin > cMin || (nMin == cMin && nPatch > cPatch), nil
+	default:
+		return false, fmt.Errorf("unknown auto-install policy %q", p)
+	}
+}
+
+// goVersionComponents parses "go1.22.6" into (1, 22, 6). A missing patch
+// component (e.g. "go1.22") is treated as patch 0, and a pre-release
+// suffix (e.g. "go1.23rc1") is truncated at the first non-digit rune,
+// which is enough precision for autoInstallPolicy's comparisons.
+func goVersionComponents(v string) (major, minor, patch int, err error) {
+	v = strings.TrimPrefix(v, "go")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, 0, fmt.Errorf("invalid go version %q", v)
+	}
+	if major, err = strconv.Atoi(leadingDigits(parts[0])); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid go version %q: %w", v, err)
+	}
+	// parts[1] may still carry a prerelease suffix with no further dot,
+	// e.g. "23rc1" out of "go1.23rc1"; only the leading digits are the
+	// minor version.
+	if minor, err = strconv.Atoi(leadingDigits(parts[1])); err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid go version %q: %w", v, err)
+	}
+	if len(parts) == 3 {
+		patch, _ = strconv.Atoi(leadingDigits(parts[2]))
+	}
+	return major, minor, patch, nil
+}
+
+// leadingDigits returns the longest prefix of s consisting of digits.
+func leadingDigits(s string) string {
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// releaseChangelogURL builds a best-effort link to the release notes for
+// version. go.dev publishes notes per minor version, not per patch, so the
+// anchor is truncated to major.minor.
+func releaseChangelogURL(version string) string {
+	major, minor, _, err := goVersionComponents(version)
+	if err != nil {
+		return "https://go.dev/doc/devel/release"
+	}
+	return fmt.Sprintf("https://go.dev/doc/devel/release#go%d.%d", major, minor)
+}
+
+// watch polls go.dev for a new stable release every interval, notifying
+// notifiers and, per policy, installing it automatically. It runs until
+// the process is killed, which is the point: --install-service wraps this
+// same flow in a systemd unit or launchd agent.
+func watch(interval time.Duration, policy autoInstallPolicy, notifiers []notifier, quiet bool) error {
+	log("Watching for new Go releases every %s (auto-install=%s)", interval, policy)
+	for {
+		if err := checkForUpdate(policy, notifiers, quiet); err != nil {
+			warn("update check failed: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// checkForUpdate fetches the latest stable version, notifies on a change,
+// and installs it if policy allows. It's split out from watch so a single
+// check (e.g. for tests, or a future '--watch-once') doesn't need the
+// infinite loop.
+func checkForUpdate(policy autoInstallPolicy, notifiers []notifier, quiet bool) error {
+	latest, err := fetchLatestVersion()
+	if err != nil {
+		return fmt.Errorf("fetch latest version: %w", err)
+	}
+	current, err := getInstalledGoVersion()
+	if err != nil {
+		current = ""
+	}
+	if current == latest {
+		return nil
+	}
+
+	update := versionUpdate{Old: current, New: latest, ChangelogURL: releaseChangelogURL(latest)}
+	for _, n := range notifiers {
+		if err := n.notify(update); err != nil {
+			warn("notify via %T: %v", n, err)
+		}
+	}
+
+	install, err := policy.allows(current, latest)
+	if err != nil {
+		warn("auto-install policy check: %v", err)
+		return nil
+	}
+	if !install {
+		return nil
+	}
+
+	log("Auto-installing %s per --auto-install=%s", latest, policy)
+	return installVersion(latest, quiet)
+}
+
+// installVersion runs the resolve-verify-install flow non-interactively:
+// it always updates the current user's PATH and never touches the
+// system-wide one, since it may run unattended from --watch or a
+// systemd/launchd service with no terminal attached.
+func installVersion(version string, quiet bool) error {
+	goos, goarch, err := resolveTarget()
+	if err != nil {
+		return err
+	}
+	plat, err := currentPlatform()
+	if err != nil {
+		return err
+	}
+
+	tarPath := filepath.Join(os.TempDir(), fmt.Sprintf("%s.%s-%s.%s", version, goos, goarch, plat.archiveExt()))
+	url := archiveDownloadURL(filepath.Base(tarPath))
+	if err := resolveArchive(version, goos, goarch, tarPath, url, quiet); err != nil {
+		return err
+	}
+
+	goroot, err := systemInstaller{}.Install(version, tarPath)
+	if err != nil {
+		return err
+	}
+	if err := plat.ensureUserPath(goroot); err != nil {
+		warn("ensure user PATH: %v", err)
+	}
+	log("Installed %s to %s", version, goroot)
+	return nil
+}
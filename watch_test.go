@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestGoVersionComponents(t *testing.T) {
+	tests := []struct {
+		in                   string
+		major, minor, patch int
+		wantErr              bool
+	}{
+		{"go1.22.6", 1, 22, 6, false},
+		{"go1.22", 1, 22, 0, false},
+		{"go1.23rc1", 1, 23, 0, false},
+		{"go1.24beta1", 1, 24, 0, false},
+		{"garbage", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		major, minor, patch, err := goVersionComponents(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("goVersionComponents(%q): expected error, got nil", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("goVersionComponents(%q): unexpected error: %v", tt.in, err)
+		}
+		if major != tt.major || minor != tt.minor || patch != tt.patch {
+			t.Errorf("goVersionComponents(%q) = (%d, %d, %d); want (%d, %d, %d)",
+				tt.in, major, minor, patch, tt.major, tt.minor, tt.patch)
+		}
+	}
+}
+
+func TestAutoInstallPolicyAllows(t *testing.T) {
+	tests := []struct {
+		policy          autoInstallPolicy
+		current, latest string
+		want            bool
+	}{
+		{autoInstallNone, "go1.22.5", "go1.22.6", false},
+		{autoInstallPatch, "go1.22.5", "go1.22.6", true},
+		{autoInstallPatch, "go1.22.5", "go1.23.0", false},
+		{autoInstallMinor, "go1.22.5", "go1.23.0", true},
+		{autoInstallMinor, "go1.22.5", "go2.0.0", false},
+		{autoInstallPatch, "", "go1.22.6", false},
+		{autoInstallPatch, "go1.22.6", "go1.22.6", false},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.policy.allows(tt.current, tt.latest)
+		if err != nil {
+			t.Fatalf("%s.allows(%q, %q): unexpected error: %v", tt.policy, tt.current, tt.latest, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s.allows(%q, %q) = %v; want %v", tt.policy, tt.current, tt.latest, got, tt.want)
+		}
+	}
+}